@@ -1,28 +1,38 @@
 package spool
 
 import (
-	"errors"
-	"fmt"
+	"context"
 	"net"
-	"sync"
+	"time"
 )
 
-// channelPool implements the Pool interface based on buffered channels.
+// channelPool implements the Pool interface. It's a thin net.Conn-flavored
+// wrapper around the generic ResourcePool[net.Conn] engine in genpool.go.
 type channelPool struct {
-	// storage for our net.Conn connections
-	mu    sync.Mutex
-	conns chan net.Conn
-	// net.Conn generator
-	connPool ConnPool
-	//had created pool num
-	hadCreatedPool int
-	//create max pool num
-	maxPoolNum int
+	inner ResourcePool[net.Conn]
 }
 
 // Factory is a function to create new connections.
 type ConnPool func() (net.Conn, error)
 
+// ChannelPoolConfig holds the configuration for NewChannelPoolConfig. Factory
+// is the only required field; the rest are optional health-check knobs.
+type ChannelPoolConfig struct {
+	InitialCap int
+	MaxCap     int
+	Factory    ConnPool
+
+	// IsActive, if set, is used by Get() and the reaper to discard conns
+	// that are no longer usable (e.g. a dead TCP connection).
+	IsActive func(net.Conn) bool
+	// MaxIdleTime, if non-zero, bounds how long a conn may sit unused in
+	// the pool before it's closed and replaced.
+	MaxIdleTime time.Duration
+	// MaxLifetime, if non-zero, bounds the total time a conn may live,
+	// regardless of how recently it was used.
+	MaxLifetime time.Duration
+}
+
 // NewChannelPool returns a new pool based on buffered channels with an initial
 // capacity and maximum capacity. Factory is used when initial capacity is
 // greater than zero to fill the pool. A zero initialCap doesn't fill the Pool
@@ -30,110 +40,68 @@ type ConnPool func() (net.Conn, error)
 // available in the pool, a new connection will be created via the Factory()
 // method.
 func NewChannelPool(initialCap, maxCap int, connPool ConnPool) (Pool, error) {
-	if initialCap < 0 || maxCap <= 0 || initialCap > maxCap {
-		return nil, errors.New("invalid capacity settings")
-	}
-
-	c := &channelPool{
-		conns:          make(chan net.Conn, maxCap),
-		connPool:       connPool,
-		hadCreatedPool: initialCap,
-		maxPoolNum:     maxCap,
-	}
-
-	// create initial connections, if something goes wrong,
-	// just close the pool error out.
-	for i := 0; i < initialCap; i++ {
-		conn, err := connPool()
-		if err != nil {
-			c.Close()
-			return nil, fmt.Errorf("conn is not able to fill the pool: %s", err)
-		}
-		c.conns <- conn
-	}
-
-	return c, nil
+	return NewChannelPoolConfig(&ChannelPoolConfig{
+		InitialCap: initialCap,
+		MaxCap:     maxCap,
+		Factory:    connPool,
+	})
 }
 
-func (c *channelPool) getConns() chan net.Conn {
-	c.mu.Lock()
-	conns := c.conns
-	c.mu.Unlock()
-	return conns
+// NewChannelPoolConfig returns a new pool based on buffered channels, with
+// optional health checking: conns failing the IsActive check or exceeding
+// MaxIdleTime/MaxLifetime are discarded and replaced instead of being handed
+// out. When any of those are set, a background reaper keeps at least
+// InitialCap healthy conns in the pool until Close() is called.
+func NewChannelPoolConfig(cfg *ChannelPoolConfig) (Pool, error) {
+	inner, err := NewGenChannelPool(&GenChannelPoolConfig[net.Conn]{
+		InitialCap: cfg.InitialCap,
+		MaxCap:     cfg.MaxCap,
+		Factory:    cfg.Factory,
+		Close:      func(conn net.Conn) error { return conn.Close() },
+
+		IsActive:    cfg.IsActive,
+		MaxIdleTime: cfg.MaxIdleTime,
+		MaxLifetime: cfg.MaxLifetime,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &channelPool{inner: inner}, nil
 }
 
 // Get implements the Pool interfaces Get() method. If there is no new
 // connection available in the pool, a new connection will be created via the
-// Factory() method.
+// Factory() method. Pooled conns that fail the IsActive check or have
+// exceeded MaxIdleTime/MaxLifetime are discarded and replaced.
 func (c *channelPool) Get() (net.Conn, error) {
-	conns := c.getConns()
-	if conns == nil {
-		return nil, ErrClosed
-	}
-
-	// wrap our connections with out custom net.Conn implementation (wrapConn
-	// method) that puts the connection back to the pool if it's closed.
-	// if pool num gt max pool, it can not be created any more
-	select {
-	case conn := <-conns:
-		if conn == nil {
-			return nil, ErrClosed
-		}
-
-		return c.wrapConn(conn), nil
-	default:
-		if c.hadCreatedPool <= c.maxPoolNum {
-			conn, err := c.connPool()
-			if err != nil {
-				return nil, err
-			}
-			c.hadCreatedPool += 1
-			return c.wrapConn(conn), nil
-		} else {
-			return nil, ErrMax
-		}
+	item, err := c.inner.Get()
+	if err != nil {
+		return nil, err
 	}
+	return wrapConn(item), nil
 }
 
-// put puts the connection back to the pool. If the pool is full or closed,
-// conn is simply closed. A nil conn will be rejected.
-func (c *channelPool) put(conn net.Conn) error {
-	if conn == nil {
-		return errors.New("connection is nil. rejecting")
-	}
-
-	c.mu.Lock()
-	defer c.mu.Unlock()
-
-	if c.conns == nil {
-		// pool is closed, close passed connection
-		return conn.Close()
-	}
-
-	// put the resource back into the pool. If the pool is full, this will
-	// block and the default case will be executed.
-	select {
-	case c.conns <- conn:
-		return nil
-	default:
-		// pool is full, close passed connection
-		return conn.Close()
+// GetWithContext behaves like Get, except that if the pool has already
+// created maxPoolNum connections, it blocks until a connection is put back
+// into the pool, the pool is closed, or ctx is done.
+func (c *channelPool) GetWithContext(ctx context.Context) (net.Conn, error) {
+	item, err := c.inner.GetWithContext(ctx)
+	if err != nil {
+		return nil, err
 	}
+	return wrapConn(item), nil
 }
 
-func (c *channelPool) Close() {
-	c.mu.Lock()
-	conns := c.conns
-	c.conns = nil
-	c.connPool = nil
-	c.mu.Unlock()
-	if conns == nil {
-		return
-	}
-	close(conns)
-	for conn := range conns {
-		conn.Close()
+// GetWithTimeout behaves like GetWithContext, blocking up to d for a
+// connection to become available.
+func (c *channelPool) GetWithTimeout(d time.Duration) (net.Conn, error) {
+	item, err := c.inner.GetWithTimeout(d)
+	if err != nil {
+		return nil, err
 	}
+	return wrapConn(item), nil
 }
 
-func (c *channelPool) Len() int { return len(c.getConns()) }
+func (c *channelPool) Close() { c.inner.Close() }
+
+func (c *channelPool) Len() int { return c.inner.Len() }
@@ -0,0 +1,209 @@
+package spool
+
+import (
+	"context"
+	"io"
+	"net"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeConn is a minimal net.Conn that tracks whether it was closed.
+type fakeConn struct {
+	mu     sync.Mutex
+	closed bool
+}
+
+func (f *fakeConn) Read(b []byte) (int, error)  { return 0, io.EOF }
+func (f *fakeConn) Write(b []byte) (int, error) { return len(b), nil }
+func (f *fakeConn) Close() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.closed = true
+	return nil
+}
+func (f *fakeConn) isClosed() bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.closed
+}
+func (f *fakeConn) LocalAddr() net.Addr                { return nil }
+func (f *fakeConn) RemoteAddr() net.Addr               { return nil }
+func (f *fakeConn) SetDeadline(t time.Time) error      { return nil }
+func (f *fakeConn) SetReadDeadline(t time.Time) error  { return nil }
+func (f *fakeConn) SetWriteDeadline(t time.Time) error { return nil }
+
+func newTestPool(t *testing.T, initialCap, maxCap int) *channelPool {
+	t.Helper()
+	p, err := NewChannelPool(initialCap, maxCap, func() (net.Conn, error) {
+		return &fakeConn{}, nil
+	})
+	if err != nil {
+		t.Fatalf("NewChannelPool: %v", err)
+	}
+	return p.(*channelPool)
+}
+
+func TestPoolConn_MarkUnusable_ClosesUnderlyingConn(t *testing.T) {
+	p := newTestPool(t, 1, 1)
+	defer p.Close()
+
+	conn, err := p.Get()
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	pc := conn.(*PoolConn)
+	fc := pc.Conn.(*fakeConn)
+
+	pc.MarkUnusable()
+	if err := pc.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	if !fc.isClosed() {
+		t.Fatal("expected underlying conn to be closed, it wasn't")
+	}
+	if p.Len() != 0 {
+		t.Fatalf("expected pool to stay empty after discarding an unusable conn, got Len()=%d", p.Len())
+	}
+
+	// active must have been decremented, so a new conn can be created.
+	conn2, err := p.Get()
+	if err != nil {
+		t.Fatalf("Get after discard: %v", err)
+	}
+	conn2.Close()
+}
+
+func TestPoolConn_Release_IsAliasForClose(t *testing.T) {
+	p := newTestPool(t, 1, 1)
+	defer p.Close()
+
+	conn, err := p.Get()
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if err := conn.(*PoolConn).Release(); err != nil {
+		t.Fatalf("Release: %v", err)
+	}
+	if p.Len() != 1 {
+		t.Fatalf("expected conn to be returned to the pool, got Len()=%d", p.Len())
+	}
+}
+
+func TestPoolConn_MarkUnusable_RacesWithPoolClose(t *testing.T) {
+	p := newTestPool(t, 4, 4)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		conn, err := p.Get()
+		if err != nil {
+			t.Fatalf("Get: %v", err)
+		}
+		wg.Add(1)
+		go func(pc *PoolConn) {
+			defer wg.Done()
+			pc.MarkUnusable()
+			pc.Close()
+		}(conn.(*PoolConn))
+	}
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		p.Close()
+	}()
+
+	wg.Wait()
+}
+
+// TestChannelPool_GetWithContext_BlocksThenSucceedsOnRelease checks that a
+// GetWithContext call against an exhausted pool wakes up and returns promptly
+// once another goroutine releases a conn, instead of waiting out the full
+// context deadline.
+func TestChannelPool_GetWithContext_BlocksThenSucceedsOnRelease(t *testing.T) {
+	p := newTestPool(t, 1, 1)
+	defer p.Close()
+
+	conn, err := p.Get()
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+
+	type result struct {
+		conn net.Conn
+		err  error
+		took time.Duration
+	}
+	resCh := make(chan result, 1)
+	go func() {
+		start := time.Now()
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+		defer cancel()
+		got, err := p.GetWithContext(ctx)
+		resCh <- result{got, err, time.Since(start)}
+	}()
+
+	// Give the goroutine above time to actually block inside GetWithContext
+	// before we release, so this exercises the blocking path rather than
+	// racing it.
+	time.Sleep(50 * time.Millisecond)
+	if err := conn.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	select {
+	case res := <-resCh:
+		if res.err != nil {
+			t.Fatalf("GetWithContext: %v", res.err)
+		}
+		if res.took >= 2*time.Second {
+			t.Fatalf("GetWithContext took %v, want it to unblock well before the 2s deadline", res.took)
+		}
+		res.conn.Close()
+	case <-time.After(3 * time.Second):
+		t.Fatal("GetWithContext never returned")
+	}
+}
+
+// TestChannelPool_GetWithContext_UnblocksOnPoolClose checks that a blocked
+// GetWithContext call returns ErrClosed as soon as the pool is closed,
+// instead of waiting out the full context deadline.
+func TestChannelPool_GetWithContext_UnblocksOnPoolClose(t *testing.T) {
+	p := newTestPool(t, 1, 1)
+
+	conn, err := p.Get()
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	defer conn.Close()
+
+	type result struct {
+		err  error
+		took time.Duration
+	}
+	resCh := make(chan result, 1)
+	go func() {
+		start := time.Now()
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+		defer cancel()
+		_, err := p.GetWithContext(ctx)
+		resCh <- result{err, time.Since(start)}
+	}()
+
+	time.Sleep(50 * time.Millisecond)
+	p.Close()
+
+	select {
+	case res := <-resCh:
+		if res.err != ErrClosed {
+			t.Fatalf("GetWithContext after Close() = %v, want ErrClosed", res.err)
+		}
+		if res.took >= 2*time.Second {
+			t.Fatalf("GetWithContext took %v, want it to unblock well before the 2s deadline", res.took)
+		}
+	case <-time.After(3 * time.Second):
+		t.Fatal("GetWithContext never returned after pool Close()")
+	}
+}
@@ -0,0 +1,36 @@
+package spool
+
+import "net"
+
+// PoolConn is a wrapper around net.Conn to modify the behavior of
+// net.Conn's Close() method.
+type PoolConn struct {
+	net.Conn
+	item *Item[net.Conn]
+}
+
+// Close returns the connection to the pool, unless it was previously marked
+// unusable via MarkUnusable(), in which case the underlying net.Conn is
+// actually closed and the pool's conn count is decremented so a replacement
+// can be created on the next Get().
+func (p *PoolConn) Close() error {
+	return p.item.Release()
+}
+
+// Release is an alias for Close, provided for callers that find it clearer
+// to "release" a pooled connection than to "close" it.
+func (p *PoolConn) Release() error {
+	return p.item.Release()
+}
+
+// MarkUnusable marks the connection not usable any more, to let the pool
+// close it instead of returning it to the pool.
+func (p *PoolConn) MarkUnusable() {
+	p.item.MarkUnusable()
+}
+
+// wrapConn wraps an Item[net.Conn] from the generic pool engine into a
+// PoolConn net.Conn.
+func wrapConn(item *Item[net.Conn]) net.Conn {
+	return &PoolConn{Conn: item.Value, item: item}
+}
@@ -0,0 +1,460 @@
+package spool
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// ResourcePool is the generic counterpart of Pool: the same buffered-channel
+// pooling machinery, but over any resource type T (e.g. *sql.DB, a gRPC
+// ClientConn, a redis client) instead of just net.Conn. channelPool itself is
+// built on top of ResourcePool[net.Conn].
+type ResourcePool[T any] interface {
+	// Get returns an Item wrapping a pooled (or newly created) T. Releasing
+	// the Item puts it back to the pool; MarkUnusable()+Release() discards it.
+	Get() (*Item[T], error)
+
+	// GetWithContext behaves like Get, except that when the pool is at its
+	// maximum capacity it blocks until an item is released, the pool is
+	// closed, or ctx is done.
+	GetWithContext(ctx context.Context) (*Item[T], error)
+
+	// GetWithTimeout behaves like GetWithContext, blocking up to d.
+	GetWithTimeout(d time.Duration) (*Item[T], error)
+
+	// Close closes the pool and every item in it. After Close() the pool is
+	// no longer usable.
+	Close()
+
+	// Len returns the current number of idle items in the pool.
+	Len() int
+}
+
+// itemOwner is implemented by every backend engine (genChannelPool,
+// genStackPool, ...) that hands out Items, so Release() can put a value back
+// or discard it without Item needing to know which backend produced it.
+type itemOwner[T any] interface {
+	put(value T, createdAt time.Time) error
+	discard(value T) error
+}
+
+// Item wraps a pooled resource together with the bookkeeping needed to
+// return it to (or discard it from) the pool that produced it.
+type Item[T any] struct {
+	// Value is the pooled resource. It must not be used after Release().
+	Value T
+
+	mu        sync.Mutex
+	pool      itemOwner[T]
+	createdAt time.Time
+	unusable  bool
+	closed    bool
+}
+
+// Release puts the item back to the pool, unless it was previously marked
+// unusable via MarkUnusable(), in which case the underlying resource is
+// actually closed and the pool's conn count is decremented so a replacement
+// can be created on the next Get().
+func (it *Item[T]) Release() error {
+	it.mu.Lock()
+	defer it.mu.Unlock()
+
+	if it.closed {
+		return nil
+	}
+	it.closed = true
+
+	if it.unusable {
+		return it.pool.discard(it.Value)
+	}
+	return it.pool.put(it.Value, it.createdAt)
+}
+
+// MarkUnusable marks the item not usable any more, to let the pool close it
+// instead of returning it to the pool.
+func (it *Item[T]) MarkUnusable() {
+	it.mu.Lock()
+	it.unusable = true
+	it.mu.Unlock()
+}
+
+// genIdleItem wraps a pooled resource together with the timestamps needed to
+// enforce IsActive checks and MaxIdleTime/MaxLifetime bounds.
+type genIdleItem[T any] struct {
+	value      T
+	createdAt  time.Time
+	lastUsedAt time.Time
+}
+
+// GenChannelPoolConfig holds the configuration for NewGenChannelPool.
+// Factory and Close are required; the rest are optional health-check knobs.
+type GenChannelPoolConfig[T any] struct {
+	InitialCap int
+	MaxCap     int
+	// Factory creates a new resource when the pool needs one.
+	Factory func() (T, error)
+	// Close releases a resource that's being discarded from the pool.
+	Close func(T) error
+
+	// IsActive, if set, is used by Get() and the reaper to discard items
+	// that are no longer usable.
+	IsActive func(T) bool
+	// MaxIdleTime, if non-zero, bounds how long an item may sit unused in
+	// the pool before it's closed and replaced.
+	MaxIdleTime time.Duration
+	// MaxLifetime, if non-zero, bounds the total time an item may live,
+	// regardless of how recently it was used.
+	MaxLifetime time.Duration
+}
+
+// poolCore holds the state and logic shared by every backend engine
+// (genChannelPool, genStackPool, ...): capacity accounting, health checks
+// and the reaper. Only the idle-item storage and wait strategy (buffered
+// channel vs. mutex-guarded stack, ...) differ between backends, so those
+// stay on the backend types themselves.
+type poolCore[T any] struct {
+	factory func() (T, error)
+	close   func(T) error
+
+	// active is the number of items this pool has created and not yet
+	// discarded (whether idle or checked out). It's managed with atomic ops
+	// so Get()'s reserve-a-slot check/increment never races with
+	// discard()'s decrement, regardless of any backend-held lock.
+	active     int32
+	maxPoolNum int
+	closeCh    chan struct{}
+
+	initialCap  int
+	isActive    func(T) bool
+	maxIdleTime time.Duration
+	maxLifetime time.Duration
+}
+
+func (c *poolCore[T]) newIdleItem(value T) genIdleItem[T] {
+	now := time.Now()
+	return genIdleItem[T]{value: value, createdAt: now, lastUsedAt: now}
+}
+
+// isHealthy reports whether gi still passes the IsActive check and is within
+// its MaxIdleTime/MaxLifetime bounds.
+func (c *poolCore[T]) isHealthy(gi genIdleItem[T]) bool {
+	now := time.Now()
+	if c.maxIdleTime > 0 && now.Sub(gi.lastUsedAt) > c.maxIdleTime {
+		return false
+	}
+	if c.maxLifetime > 0 && now.Sub(gi.createdAt) > c.maxLifetime {
+		return false
+	}
+	if c.isActive != nil && !c.isActive(gi.value) {
+		return false
+	}
+	return true
+}
+
+// discard closes value and decrements active, making room for a replacement
+// to be created.
+func (c *poolCore[T]) discard(value T) error {
+	err := c.close(value)
+	atomic.AddInt32(&c.active, -1)
+	return err
+}
+
+// reserveSlot atomically claims one unit of capacity if active < maxPoolNum,
+// using a compare-and-swap loop so active is never observed above
+// maxPoolNum, not even transiently.
+func (c *poolCore[T]) reserveSlot() bool {
+	for {
+		cur := atomic.LoadInt32(&c.active)
+		if cur >= int32(c.maxPoolNum) {
+			return false
+		}
+		if atomic.CompareAndSwapInt32(&c.active, cur, cur+1) {
+			return true
+		}
+	}
+}
+
+// refill reserves capacity and creates up to need new idle items, handing
+// each to push to be stored by the backend. If push reports failure (the
+// backend had no room after all), the reserved slot is released via
+// discard instead of leaking it. Stops early once active reaches
+// maxPoolNum, so a reap tick never creates more real resources than
+// MaxCap allows, even while other items are currently checked out.
+func (c *poolCore[T]) refill(need int, push func(genIdleItem[T]) bool) {
+	for i := 0; i < need; i++ {
+		if !c.reserveSlot() {
+			break
+		}
+		value, err := c.factory()
+		if err != nil {
+			atomic.AddInt32(&c.active, -1)
+			break
+		}
+		if !push(c.newIdleItem(value)) {
+			c.discard(value)
+		}
+	}
+}
+
+// reapInterval picks how often the reaper runs, based on the shortest
+// configured bound so expired items don't linger much past their deadline.
+func (c *poolCore[T]) reapInterval() time.Duration {
+	interval := time.Duration(0)
+	for _, d := range []time.Duration{c.maxIdleTime, c.maxLifetime} {
+		if d > 0 && (interval == 0 || d < interval) {
+			interval = d
+		}
+	}
+	if interval <= 0 {
+		interval = 30 * time.Second
+	}
+	return interval / 2
+}
+
+// startReaper periodically calls reap until Close() fires closeCh.
+func (c *poolCore[T]) startReaper(reap func()) {
+	ticker := time.NewTicker(c.reapInterval())
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			reap()
+		case <-c.closeCh:
+			return
+		}
+	}
+}
+
+// genChannelPool implements ResourcePool[T] based on buffered channels. It is
+// the generic engine that channelPool specializes to net.Conn.
+type genChannelPool[T any] struct {
+	mu    sync.Mutex
+	items chan genIdleItem[T]
+
+	poolCore[T]
+}
+
+// NewGenChannelPool returns a new ResourcePool based on buffered channels,
+// with optional health checking: items failing the IsActive check or
+// exceeding MaxIdleTime/MaxLifetime are discarded and replaced instead of
+// being handed out. When any of those are set, a background reaper keeps at
+// least InitialCap healthy items in the pool until Close() is called.
+func NewGenChannelPool[T any](cfg *GenChannelPoolConfig[T]) (ResourcePool[T], error) {
+	if cfg.InitialCap < 0 || cfg.MaxCap <= 0 || cfg.InitialCap > cfg.MaxCap {
+		return nil, errors.New("invalid capacity settings")
+	}
+	if cfg.Factory == nil || cfg.Close == nil {
+		return nil, errors.New("factory and close funcs are required")
+	}
+
+	c := &genChannelPool[T]{
+		items: make(chan genIdleItem[T], cfg.MaxCap),
+		poolCore: poolCore[T]{
+			factory:     cfg.Factory,
+			close:       cfg.Close,
+			active:      int32(cfg.InitialCap),
+			maxPoolNum:  cfg.MaxCap,
+			closeCh:     make(chan struct{}),
+			initialCap:  cfg.InitialCap,
+			isActive:    cfg.IsActive,
+			maxIdleTime: cfg.MaxIdleTime,
+			maxLifetime: cfg.MaxLifetime,
+		},
+	}
+
+	// create initial items, if something goes wrong, just close the pool
+	// and error out.
+	for i := 0; i < cfg.InitialCap; i++ {
+		value, err := cfg.Factory()
+		if err != nil {
+			c.Close()
+			return nil, fmt.Errorf("item is not able to fill the pool: %s", err)
+		}
+		c.items <- c.newIdleItem(value)
+	}
+
+	if c.isActive != nil || c.maxIdleTime > 0 || c.maxLifetime > 0 {
+		go c.startReaper(c.reap)
+	}
+
+	return c, nil
+}
+
+func (c *genChannelPool[T]) getItems() chan genIdleItem[T] {
+	c.mu.Lock()
+	items := c.items
+	c.mu.Unlock()
+	return items
+}
+
+func (c *genChannelPool[T]) newItem(value T, createdAt time.Time) *Item[T] {
+	return &Item[T]{Value: value, pool: c, createdAt: createdAt}
+}
+
+// Get implements ResourcePool's Get() method. If there is no item available
+// in the pool, a new one will be created via the Factory() method. Pooled
+// items that fail the IsActive check or have exceeded MaxIdleTime/MaxLifetime
+// are discarded and replaced.
+func (c *genChannelPool[T]) Get() (*Item[T], error) {
+	items := c.getItems()
+	if items == nil {
+		return nil, ErrClosed
+	}
+
+	for {
+		select {
+		case gi, ok := <-items:
+			if !ok {
+				return nil, ErrClosed
+			}
+			if !c.isHealthy(gi) {
+				c.discard(gi.value)
+				continue
+			}
+			return c.newItem(gi.value, gi.createdAt), nil
+		default:
+			if !c.reserveSlot() {
+				return nil, ErrMax
+			}
+			value, err := c.factory()
+			if err != nil {
+				atomic.AddInt32(&c.active, -1)
+				return nil, err
+			}
+			return c.newItem(value, time.Now()), nil
+		}
+	}
+}
+
+// GetWithContext behaves like Get, except that if the pool has already
+// created maxPoolNum items, it blocks until an item is released, the pool is
+// closed, or ctx is done.
+func (c *genChannelPool[T]) GetWithContext(ctx context.Context) (*Item[T], error) {
+	for {
+		item, err := c.Get()
+		if err != ErrMax {
+			return item, err
+		}
+
+		items := c.getItems()
+		if items == nil {
+			return nil, ErrClosed
+		}
+
+		select {
+		case gi, ok := <-items:
+			if !ok {
+				return nil, ErrClosed
+			}
+			if !c.isHealthy(gi) {
+				c.discard(gi.value)
+				continue
+			}
+			return c.newItem(gi.value, gi.createdAt), nil
+		case <-c.closeCh:
+			return nil, ErrClosed
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// GetWithTimeout behaves like GetWithContext, blocking up to d for an item to
+// become available.
+func (c *genChannelPool[T]) GetWithTimeout(d time.Duration) (*Item[T], error) {
+	ctx, cancel := context.WithTimeout(context.Background(), d)
+	defer cancel()
+	return c.GetWithContext(ctx)
+}
+
+// put puts value back to the pool. If the pool is closed, value is simply
+// closed. If the pool is full, value is discarded via discard() so active
+// stays accurate.
+func (c *genChannelPool[T]) put(value T, createdAt time.Time) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.items == nil {
+		// pool is closed, close passed value
+		return c.close(value)
+	}
+
+	select {
+	case c.items <- genIdleItem[T]{value: value, createdAt: createdAt, lastUsedAt: time.Now()}:
+		return nil
+	default:
+		// pool is full, discard the passed value so active stays accurate.
+		return c.discard(value)
+	}
+}
+
+// reap drains the pool, discarding items that are no longer healthy, and
+// tops back up to initialCap.
+func (c *genChannelPool[T]) reap() {
+	items := c.getItems()
+	if items == nil {
+		return
+	}
+
+	var kept []genIdleItem[T]
+drain:
+	for {
+		select {
+		case gi := <-items:
+			if c.isHealthy(gi) {
+				kept = append(kept, gi)
+			} else {
+				c.discard(gi.value)
+			}
+		default:
+			break drain
+		}
+	}
+
+	for _, gi := range kept {
+		select {
+		case items <- gi:
+		default:
+			c.discard(gi.value)
+		}
+	}
+
+	// Compute need from items' actual post-repush length, not len(kept): a
+	// concurrent put() may have filled the channel between the drain and the
+	// repush loop above, in which case some kept items lost the race for a
+	// slot and were discarded, and need must account for that too.
+	c.mu.Lock()
+	need := c.initialCap - len(items)
+	c.mu.Unlock()
+
+	c.refill(need, func(gi genIdleItem[T]) bool {
+		select {
+		case items <- gi:
+			return true
+		default:
+			return false
+		}
+	})
+}
+
+func (c *genChannelPool[T]) Close() {
+	c.mu.Lock()
+	items := c.items
+	c.items = nil
+	c.mu.Unlock()
+	if items == nil {
+		return
+	}
+	close(c.closeCh)
+	close(items)
+	for gi := range items {
+		c.close(gi.value)
+	}
+}
+
+func (c *genChannelPool[T]) Len() int { return len(c.getItems()) }
@@ -0,0 +1,347 @@
+package spool
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestGenChannelPool_IntResource(t *testing.T) {
+	next := 0
+	closed := make(map[int]bool)
+
+	p, err := NewGenChannelPool(&GenChannelPoolConfig[int]{
+		InitialCap: 1,
+		MaxCap:     2,
+		Factory: func() (int, error) {
+			next++
+			return next, nil
+		},
+		Close: func(v int) error {
+			closed[v] = true
+			return nil
+		},
+	})
+	if err != nil {
+		t.Fatalf("NewGenChannelPool: %v", err)
+	}
+	defer p.Close()
+
+	item, err := p.Get()
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if item.Value != 1 {
+		t.Fatalf("expected first factory value 1, got %d", item.Value)
+	}
+
+	if err := item.Release(); err != nil {
+		t.Fatalf("Release: %v", err)
+	}
+	if p.Len() != 1 {
+		t.Fatalf("expected released item back in pool, got Len()=%d", p.Len())
+	}
+
+	item2, err := p.Get()
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	item2.MarkUnusable()
+	if err := item2.Release(); err != nil {
+		t.Fatalf("Release: %v", err)
+	}
+	if !closed[1] {
+		t.Fatal("expected unusable item to be closed via the Close func")
+	}
+	if p.Len() != 0 {
+		t.Fatalf("expected pool empty after discarding the unusable item, got Len()=%d", p.Len())
+	}
+}
+
+// TestGenChannelPool_ConcurrentGetRelease_KeepsActiveConsistent hammers a
+// small pool with many more goroutines than MaxCap, each cycling Get/Release
+// (some marking their item unusable), and checks that active never drifts
+// above MaxCap or below zero and that Len() never exceeds it either.
+func TestGenChannelPool_ConcurrentGetRelease_KeepsActiveConsistent(t *testing.T) {
+	const maxCap = 4
+	const goroutines = 50
+	const iterations = 200
+
+	var created int32
+	p, err := NewGenChannelPool(&GenChannelPoolConfig[int]{
+		InitialCap: 1,
+		MaxCap:     maxCap,
+		Factory: func() (int, error) {
+			return int(atomic.AddInt32(&created, 1)), nil
+		},
+		Close: func(int) error { return nil },
+	})
+	if err != nil {
+		t.Fatalf("NewGenChannelPool: %v", err)
+	}
+	defer p.Close()
+
+	gc := p.(*genChannelPool[int])
+
+	var wg sync.WaitGroup
+	for g := 0; g < goroutines; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < iterations; i++ {
+				item, err := p.Get()
+				if err == ErrMax {
+					continue
+				}
+				if err != nil {
+					t.Errorf("Get: %v", err)
+					return
+				}
+				if g%7 == 0 {
+					item.MarkUnusable()
+				}
+				if err := item.Release(); err != nil {
+					t.Errorf("Release: %v", err)
+					return
+				}
+
+				active := atomic.LoadInt32(&gc.active)
+				if active < 0 || active > maxCap {
+					t.Errorf("active out of bounds: %d", active)
+					return
+				}
+			}
+		}(g)
+	}
+	wg.Wait()
+
+	if l := p.Len(); l > maxCap {
+		t.Fatalf("Len() = %d, want <= %d", l, maxCap)
+	}
+	if active := atomic.LoadInt32(&gc.active); active < 0 || active > maxCap {
+		t.Fatalf("final active = %d, want in [0, %d]", active, maxCap)
+	}
+}
+
+// TestGenChannelPool_IsActive_DiscardsAndReplacesUnhealthyItem checks that
+// Get() discards an idle item that fails the IsActive check and hands back a
+// freshly created one instead.
+func TestGenChannelPool_IsActive_DiscardsAndReplacesUnhealthyItem(t *testing.T) {
+	next := 0
+	var mu sync.Mutex
+	healthy := make(map[int]bool)
+	closedVals := make(map[int]bool)
+
+	p, err := NewGenChannelPool(&GenChannelPoolConfig[int]{
+		InitialCap: 1,
+		MaxCap:     1,
+		Factory: func() (int, error) {
+			next++
+			mu.Lock()
+			healthy[next] = true
+			mu.Unlock()
+			return next, nil
+		},
+		Close: func(v int) error {
+			mu.Lock()
+			closedVals[v] = true
+			mu.Unlock()
+			return nil
+		},
+		IsActive: func(v int) bool {
+			mu.Lock()
+			defer mu.Unlock()
+			return healthy[v]
+		},
+	})
+	if err != nil {
+		t.Fatalf("NewGenChannelPool: %v", err)
+	}
+	defer p.Close()
+
+	item, err := p.Get()
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if err := item.Release(); err != nil {
+		t.Fatalf("Release: %v", err)
+	}
+
+	mu.Lock()
+	healthy[1] = false
+	mu.Unlock()
+
+	item2, err := p.Get()
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if item2.Value != 2 {
+		t.Fatalf("expected Get() to discard the unhealthy item and create a new one, got %d", item2.Value)
+	}
+	if !closedVals[1] {
+		t.Fatal("expected the unhealthy item to be closed via the Close func")
+	}
+}
+
+// TestGenChannelPool_MaxIdleTime_DiscardsExpiredItemOnGet checks that Get()
+// discards an idle item that has exceeded MaxIdleTime and hands back a
+// freshly created one instead.
+func TestGenChannelPool_MaxIdleTime_DiscardsExpiredItemOnGet(t *testing.T) {
+	next := 0
+	p, err := NewGenChannelPool(&GenChannelPoolConfig[int]{
+		InitialCap: 1,
+		MaxCap:     1,
+		Factory: func() (int, error) {
+			next++
+			return next, nil
+		},
+		Close:       func(int) error { return nil },
+		MaxIdleTime: 20 * time.Millisecond,
+	})
+	if err != nil {
+		t.Fatalf("NewGenChannelPool: %v", err)
+	}
+	defer p.Close()
+
+	item, err := p.Get()
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if err := item.Release(); err != nil {
+		t.Fatalf("Release: %v", err)
+	}
+
+	time.Sleep(30 * time.Millisecond)
+
+	item2, err := p.Get()
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if item2.Value != 2 {
+		t.Fatalf("expected Get() to discard the idle-expired item and create a new one, got %d", item2.Value)
+	}
+}
+
+// TestGenChannelPool_MaxLifetime_DiscardsExpiredItemOnGet checks the same
+// path as above for MaxLifetime, which bounds total age regardless of how
+// recently an item was used.
+func TestGenChannelPool_MaxLifetime_DiscardsExpiredItemOnGet(t *testing.T) {
+	next := 0
+	p, err := NewGenChannelPool(&GenChannelPoolConfig[int]{
+		InitialCap: 1,
+		MaxCap:     1,
+		Factory: func() (int, error) {
+			next++
+			return next, nil
+		},
+		Close:       func(int) error { return nil },
+		MaxLifetime: 20 * time.Millisecond,
+	})
+	if err != nil {
+		t.Fatalf("NewGenChannelPool: %v", err)
+	}
+	defer p.Close()
+
+	item, err := p.Get()
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if err := item.Release(); err != nil {
+		t.Fatalf("Release: %v", err)
+	}
+
+	time.Sleep(30 * time.Millisecond)
+
+	item2, err := p.Get()
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if item2.Value != 2 {
+		t.Fatalf("expected Get() to discard the lifetime-expired item and create a new one, got %d", item2.Value)
+	}
+}
+
+// TestGenChannelPool_Reap_RefillsWithoutExceedingMaxCapWhileItemsCheckedOut
+// reproduces the scenario that used to double a busy pool's real resource
+// count: check out every item (so none are idle for the reaper to drain),
+// then trigger a reap tick directly. It must not push active past MaxCap.
+func TestGenChannelPool_Reap_RefillsWithoutExceedingMaxCapWhileItemsCheckedOut(t *testing.T) {
+	next := 0
+	p, err := NewGenChannelPool(&GenChannelPoolConfig[int]{
+		InitialCap: 2,
+		MaxCap:     2,
+		Factory: func() (int, error) {
+			next++
+			return next, nil
+		},
+		Close:       func(int) error { return nil },
+		MaxIdleTime: time.Hour, // never expires on its own; reap is triggered manually below.
+	})
+	if err != nil {
+		t.Fatalf("NewGenChannelPool: %v", err)
+	}
+	defer p.Close()
+
+	gc := p.(*genChannelPool[int])
+
+	item1, err := p.Get()
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	item2, err := p.Get()
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+
+	gc.reap()
+
+	if active := atomic.LoadInt32(&gc.active); active > 2 {
+		t.Fatalf("reap created more resources than MaxCap while both conns were checked out: active=%d", active)
+	}
+
+	if err := item1.Release(); err != nil {
+		t.Fatalf("Release: %v", err)
+	}
+	if err := item2.Release(); err != nil {
+		t.Fatalf("Release: %v", err)
+	}
+
+	if active := atomic.LoadInt32(&gc.active); active > 2 {
+		t.Fatalf("active exceeded MaxCap after releasing both conns: active=%d", active)
+	}
+}
+
+// TestGenChannelPool_Reap_DiscardsExpiredIdleItemsAndRefillsToInitialCap
+// checks the reaper's normal (nothing checked out) path: idle items that
+// have gone stale are discarded and replaced so the pool comes back to
+// InitialCap healthy items.
+func TestGenChannelPool_Reap_DiscardsExpiredIdleItemsAndRefillsToInitialCap(t *testing.T) {
+	next := 0
+	p, err := NewGenChannelPool(&GenChannelPoolConfig[int]{
+		InitialCap:  2,
+		MaxCap:      2,
+		Factory:     func() (int, error) { next++; return next, nil },
+		Close:       func(int) error { return nil },
+		MaxIdleTime: 10 * time.Millisecond,
+	})
+	if err != nil {
+		t.Fatalf("NewGenChannelPool: %v", err)
+	}
+	defer p.Close()
+
+	gc := p.(*genChannelPool[int])
+
+	time.Sleep(20 * time.Millisecond)
+	gc.reap()
+
+	if l := p.Len(); l != 2 {
+		t.Fatalf("expected reap to refill the pool back to InitialCap, got Len()=%d", l)
+	}
+	if next <= 2 {
+		t.Fatalf("expected reap to create replacement items via the factory, it was only called %d times", next)
+	}
+	if active := atomic.LoadInt32(&gc.active); active != 2 {
+		t.Fatalf("active = %d after reap, want 2 (InitialCap)", active)
+	}
+}
@@ -0,0 +1,235 @@
+package spool
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// genStackPool implements ResourcePool[T] as a LIFO stack instead of
+// genChannelPool's FIFO channel: Get() hands back the most recently released
+// item first. Handing out the most-recently-used item keeps the rest of the
+// stack colder, which tends to play nicer with keep-alive timeouts and lets
+// idle items further down age out and get reaped instead of being cycled
+// back into use. It shares poolCore (capacity accounting, health checks, the
+// reaper) with genChannelPool; only the storage and wait strategy differ.
+type genStackPool[T any] struct {
+	mu     sync.Mutex
+	items  []genIdleItem[T]
+	closed bool
+
+	// avail is signaled (non-blocking send) whenever an item is pushed back
+	// onto the stack, so blocked Get callers can wake up and retry the pop.
+	avail chan struct{}
+
+	poolCore[T]
+}
+
+// NewGenStackPool returns a new ResourcePool with LIFO hand-out order,
+// otherwise behaving like NewGenChannelPool: optional IsActive/MaxIdleTime/
+// MaxLifetime health checks and a reaper keeping InitialCap healthy items
+// around until Close() is called.
+func NewGenStackPool[T any](cfg *GenChannelPoolConfig[T]) (ResourcePool[T], error) {
+	if cfg.InitialCap < 0 || cfg.MaxCap <= 0 || cfg.InitialCap > cfg.MaxCap {
+		return nil, errors.New("invalid capacity settings")
+	}
+	if cfg.Factory == nil || cfg.Close == nil {
+		return nil, errors.New("factory and close funcs are required")
+	}
+
+	c := &genStackPool[T]{
+		items: make([]genIdleItem[T], 0, cfg.MaxCap),
+		avail: make(chan struct{}, cfg.MaxCap),
+		poolCore: poolCore[T]{
+			factory:     cfg.Factory,
+			close:       cfg.Close,
+			active:      int32(cfg.InitialCap),
+			maxPoolNum:  cfg.MaxCap,
+			closeCh:     make(chan struct{}),
+			initialCap:  cfg.InitialCap,
+			isActive:    cfg.IsActive,
+			maxIdleTime: cfg.MaxIdleTime,
+			maxLifetime: cfg.MaxLifetime,
+		},
+	}
+
+	for i := 0; i < cfg.InitialCap; i++ {
+		value, err := cfg.Factory()
+		if err != nil {
+			c.Close()
+			return nil, fmt.Errorf("item is not able to fill the pool: %s", err)
+		}
+		c.items = append(c.items, c.newIdleItem(value))
+	}
+
+	if c.isActive != nil || c.maxIdleTime > 0 || c.maxLifetime > 0 {
+		go c.startReaper(c.reap)
+	}
+
+	return c, nil
+}
+
+// pop removes and returns the top (most recently pushed) item, if any.
+func (c *genStackPool[T]) pop() (genIdleItem[T], bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	n := len(c.items)
+	if n == 0 {
+		return genIdleItem[T]{}, false
+	}
+	gi := c.items[n-1]
+	c.items = c.items[:n-1]
+	return gi, true
+}
+
+func (c *genStackPool[T]) newItem(value T, createdAt time.Time) *Item[T] {
+	return &Item[T]{Value: value, pool: c, createdAt: createdAt}
+}
+
+// Get implements ResourcePool's Get() method, handing out the most recently
+// released item. Pooled items that fail the IsActive check or have exceeded
+// MaxIdleTime/MaxLifetime are discarded and replaced.
+func (c *genStackPool[T]) Get() (*Item[T], error) {
+	c.mu.Lock()
+	closed := c.closed
+	c.mu.Unlock()
+	if closed {
+		return nil, ErrClosed
+	}
+
+	for {
+		if gi, ok := c.pop(); ok {
+			if !c.isHealthy(gi) {
+				c.discard(gi.value)
+				continue
+			}
+			return c.newItem(gi.value, gi.createdAt), nil
+		}
+
+		if !c.reserveSlot() {
+			return nil, ErrMax
+		}
+		value, err := c.factory()
+		if err != nil {
+			atomic.AddInt32(&c.active, -1)
+			return nil, err
+		}
+		return c.newItem(value, time.Now()), nil
+	}
+}
+
+// GetWithContext behaves like Get, except that if the pool has already
+// created maxPoolNum items, it blocks until an item is released, the pool is
+// closed, or ctx is done.
+func (c *genStackPool[T]) GetWithContext(ctx context.Context) (*Item[T], error) {
+	for {
+		item, err := c.Get()
+		if err != ErrMax {
+			return item, err
+		}
+
+		select {
+		case <-c.avail:
+			continue
+		case <-c.closeCh:
+			return nil, ErrClosed
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// GetWithTimeout behaves like GetWithContext, blocking up to d for an item
+// to become available.
+func (c *genStackPool[T]) GetWithTimeout(d time.Duration) (*Item[T], error) {
+	ctx, cancel := context.WithTimeout(context.Background(), d)
+	defer cancel()
+	return c.GetWithContext(ctx)
+}
+
+// put pushes value back onto the stack. If the pool is closed or already at
+// capacity, value is discarded so active stays accurate.
+func (c *genStackPool[T]) put(value T, createdAt time.Time) error {
+	c.mu.Lock()
+	if c.closed {
+		c.mu.Unlock()
+		return c.close(value)
+	}
+	if len(c.items) >= c.maxPoolNum {
+		c.mu.Unlock()
+		return c.discard(value)
+	}
+	c.items = append(c.items, genIdleItem[T]{value: value, createdAt: createdAt, lastUsedAt: time.Now()})
+	c.mu.Unlock()
+
+	select {
+	case c.avail <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+// reap drains the stack, discarding items that are no longer healthy, and
+// tops back up to initialCap without exceeding maxPoolNum even if some
+// items are currently checked out (see poolCore.refill).
+func (c *genStackPool[T]) reap() {
+	c.mu.Lock()
+	if c.closed {
+		c.mu.Unlock()
+		return
+	}
+	old := c.items
+	c.items = make([]genIdleItem[T], 0, c.maxPoolNum)
+	c.mu.Unlock()
+
+	var kept []genIdleItem[T]
+	for _, gi := range old {
+		if c.isHealthy(gi) {
+			kept = append(kept, gi)
+		} else {
+			c.discard(gi.value)
+		}
+	}
+
+	c.mu.Lock()
+	c.items = append(c.items, kept...)
+	need := c.initialCap - len(c.items)
+	c.mu.Unlock()
+
+	c.refill(need, func(gi genIdleItem[T]) bool {
+		c.mu.Lock()
+		if len(c.items) >= c.maxPoolNum {
+			c.mu.Unlock()
+			return false
+		}
+		c.items = append(c.items, gi)
+		c.mu.Unlock()
+		return true
+	})
+}
+
+func (c *genStackPool[T]) Close() {
+	c.mu.Lock()
+	if c.closed {
+		c.mu.Unlock()
+		return
+	}
+	c.closed = true
+	items := c.items
+	c.items = nil
+	c.mu.Unlock()
+
+	close(c.closeCh)
+	for _, gi := range items {
+		c.close(gi.value)
+	}
+}
+
+func (c *genStackPool[T]) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.items)
+}
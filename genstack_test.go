@@ -0,0 +1,159 @@
+package spool
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+func TestGenStackPool_LIFOHandOutOrder(t *testing.T) {
+	next := 0
+	p, err := NewGenStackPool(&GenChannelPoolConfig[int]{
+		InitialCap: 2,
+		MaxCap:     2,
+		Factory: func() (int, error) {
+			next++
+			return next, nil
+		},
+		Close: func(int) error { return nil },
+	})
+	if err != nil {
+		t.Fatalf("NewGenStackPool: %v", err)
+	}
+	defer p.Close()
+
+	a, err := p.Get()
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	b, err := p.Get()
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if err := a.Release(); err != nil {
+		t.Fatalf("Release: %v", err)
+	}
+	if err := b.Release(); err != nil {
+		t.Fatalf("Release: %v", err)
+	}
+
+	// b was released last, so it must be handed out first.
+	got, err := p.Get()
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if got.Value != b.Value {
+		t.Fatalf("Get() = %d, want most recently released value %d", got.Value, b.Value)
+	}
+}
+
+// TestGenStackPool_ConcurrentGetRelease_KeepsActiveConsistent mirrors
+// TestGenChannelPool_ConcurrentGetRelease_KeepsActiveConsistent for the stack
+// backend: it hammers a small pool with many more goroutines than MaxCap,
+// each cycling Get/Release (some marking their item unusable), and checks
+// that active never drifts above MaxCap or below zero.
+func TestGenStackPool_ConcurrentGetRelease_KeepsActiveConsistent(t *testing.T) {
+	const maxCap = 4
+	const goroutines = 50
+	const iterations = 200
+
+	var created int32
+	p, err := NewGenStackPool(&GenChannelPoolConfig[int]{
+		InitialCap: 1,
+		MaxCap:     maxCap,
+		Factory: func() (int, error) {
+			return int(atomic.AddInt32(&created, 1)), nil
+		},
+		Close: func(int) error { return nil },
+	})
+	if err != nil {
+		t.Fatalf("NewGenStackPool: %v", err)
+	}
+	defer p.Close()
+
+	gc := p.(*genStackPool[int])
+
+	var wg sync.WaitGroup
+	for g := 0; g < goroutines; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < iterations; i++ {
+				item, err := p.Get()
+				if err == ErrMax {
+					continue
+				}
+				if err != nil {
+					t.Errorf("Get: %v", err)
+					return
+				}
+				if g%7 == 0 {
+					item.MarkUnusable()
+				}
+				if err := item.Release(); err != nil {
+					t.Errorf("Release: %v", err)
+					return
+				}
+
+				active := atomic.LoadInt32(&gc.active)
+				if active < 0 || active > maxCap {
+					t.Errorf("active out of bounds: %d", active)
+					return
+				}
+			}
+		}(g)
+	}
+	wg.Wait()
+
+	if l := p.Len(); l > maxCap {
+		t.Fatalf("Len() = %d, want <= %d", l, maxCap)
+	}
+	if active := atomic.LoadInt32(&gc.active); active < 0 || active > maxCap {
+		t.Fatalf("final active = %d, want in [0, %d]", active, maxCap)
+	}
+}
+
+// TestGenStackPool_MarkUnusable_ClosesAndDecrementsActive checks that marking
+// an item unusable closes it via Close and frees its capacity slot so a
+// replacement can be created on the next Get().
+func TestGenStackPool_MarkUnusable_ClosesAndDecrementsActive(t *testing.T) {
+	next := 0
+	closed := make(map[int]bool)
+
+	p, err := NewGenStackPool(&GenChannelPoolConfig[int]{
+		InitialCap: 1,
+		MaxCap:     1,
+		Factory: func() (int, error) {
+			next++
+			return next, nil
+		},
+		Close: func(v int) error {
+			closed[v] = true
+			return nil
+		},
+	})
+	if err != nil {
+		t.Fatalf("NewGenStackPool: %v", err)
+	}
+	defer p.Close()
+
+	item, err := p.Get()
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	item.MarkUnusable()
+	if err := item.Release(); err != nil {
+		t.Fatalf("Release: %v", err)
+	}
+	if !closed[1] {
+		t.Fatal("expected unusable item to be closed via the Close func")
+	}
+
+	item2, err := p.Get()
+	if err != nil {
+		t.Fatalf("Get after discard: %v", err)
+	}
+	if item2.Value != 2 {
+		t.Fatalf("expected a freshly created item after discard, got %d", item2.Value)
+	}
+}
@@ -0,0 +1,40 @@
+package spool
+
+import (
+	"context"
+	"errors"
+	"net"
+	"time"
+)
+
+// ErrClosed is the error resulting if the pool is closed via pool.Close().
+var ErrClosed = errors.New("pool is closed")
+
+// ErrMax is the error returned when the pool has already created maxPoolNum
+// connections and none are available to be reused.
+var ErrMax = errors.New("maximum pool capacity reached")
+
+// Pool interface describes a pool implementation. A pool should have maximum
+// capacity. An ideal pool is threadsafe and easy to use.
+type Pool interface {
+	// Get returns a new connection from the pool. Closing the connections puts
+	// it back to the Pool. Closing it when the pool is destroyed or full will
+	// be counted as an error.
+	Get() (net.Conn, error)
+
+	// GetWithContext behaves like Get, except that when the pool is at its
+	// maximum capacity it blocks until a connection is returned to the pool,
+	// the pool is closed, or ctx is done.
+	GetWithContext(ctx context.Context) (net.Conn, error)
+
+	// GetWithTimeout behaves like GetWithContext, blocking up to d for a
+	// connection to become available.
+	GetWithTimeout(d time.Duration) (net.Conn, error)
+
+	// Close closes the pool and all its connections. After Close() the pool is
+	// no longer usable.
+	Close()
+
+	// Len returns the current number of connections of the pool.
+	Len() int
+}
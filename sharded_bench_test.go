@@ -0,0 +1,58 @@
+package spool
+
+import (
+	"net"
+	"testing"
+)
+
+// benchFactory returns conns that do nothing on Close, so these benchmarks
+// measure pool contention rather than connection teardown cost.
+func benchFactory() (net.Conn, error) { return &fakeConn{}, nil }
+
+// BenchmarkChannelPool_ParallelGetClose measures a single channelPool under
+// concurrent Get/Close, where every goroutine contends on the same buffered
+// channel.
+func BenchmarkChannelPool_ParallelGetClose(b *testing.B) {
+	p, err := NewChannelPool(64, 64, benchFactory)
+	if err != nil {
+		b.Fatalf("NewChannelPool: %v", err)
+	}
+	defer p.Close()
+
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			conn, err := p.Get()
+			if err != nil {
+				b.Fatalf("Get: %v", err)
+			}
+			conn.Close()
+		}
+	})
+}
+
+// BenchmarkShardedPool_ParallelGetClose measures the same total capacity
+// split across 8 shards, so concurrent goroutines spread across 8 separate
+// channels instead of contending on one.
+func BenchmarkShardedPool_ParallelGetClose(b *testing.B) {
+	p, err := NewShardedPool(8, &ChannelPoolConfig{
+		InitialCap: 64,
+		MaxCap:     64,
+		Factory:    benchFactory,
+	})
+	if err != nil {
+		b.Fatalf("NewShardedPool: %v", err)
+	}
+	defer p.Close()
+
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			conn, err := p.Get()
+			if err != nil {
+				b.Fatalf("Get: %v", err)
+			}
+			conn.Close()
+		}
+	})
+}
@@ -0,0 +1,69 @@
+package spool
+
+import (
+	"net"
+	"testing"
+)
+
+func TestNewShardedPool_DistributesCapacityAndServesUpToMax(t *testing.T) {
+	p, err := NewShardedPool(3, &ChannelPoolConfig{
+		InitialCap: 3,
+		MaxCap:     6,
+		Factory: func() (net.Conn, error) {
+			return &fakeConn{}, nil
+		},
+	})
+	if err != nil {
+		t.Fatalf("NewShardedPool: %v", err)
+	}
+	defer p.Close()
+
+	if got := p.Len(); got != 3 {
+		t.Fatalf("Len() = %d, want 3 (sum of InitialCap across shards)", got)
+	}
+
+	// MaxCap and shard count divide evenly (2 per shard), so round-robin
+	// dispatch visits every shard exactly twice before any of them fills up.
+	var conns []net.Conn
+	for i := 0; i < 6; i++ {
+		conn, err := p.Get()
+		if err != nil {
+			t.Fatalf("Get() #%d: %v", i, err)
+		}
+		conns = append(conns, conn)
+	}
+	if _, err := p.Get(); err != ErrMax {
+		t.Fatalf("Get() past total MaxCap = %v, want ErrMax", err)
+	}
+
+	for _, conn := range conns {
+		conn.Close()
+	}
+	if got := p.Len(); got != 6 {
+		t.Fatalf("Len() after releasing all = %d, want 6", got)
+	}
+}
+
+func TestNewShardedPool_RejectsNonPositiveShardCount(t *testing.T) {
+	_, err := NewShardedPool(0, &ChannelPoolConfig{
+		MaxCap: 1,
+		Factory: func() (net.Conn, error) {
+			return &fakeConn{}, nil
+		},
+	})
+	if err == nil {
+		t.Fatal("expected an error for a non-positive shard count")
+	}
+}
+
+func TestNewShardedPool_RejectsMoreShardsThanMaxCap(t *testing.T) {
+	_, err := NewShardedPool(5, &ChannelPoolConfig{
+		MaxCap: 3,
+		Factory: func() (net.Conn, error) {
+			return &fakeConn{}, nil
+		},
+	})
+	if err == nil {
+		t.Fatal("expected an error when shards exceeds MaxCap")
+	}
+}
@@ -0,0 +1,35 @@
+package spool
+
+import "net"
+
+// NewStackPool returns a new pool with LIFO hand-out order (Get() returns the
+// most recently released connection first) and an initial and maximum
+// capacity, otherwise behaving like NewChannelPool.
+func NewStackPool(initialCap, maxCap int, connPool ConnPool) (Pool, error) {
+	return NewStackPoolConfig(&ChannelPoolConfig{
+		InitialCap: initialCap,
+		MaxCap:     maxCap,
+		Factory:    connPool,
+	})
+}
+
+// NewStackPoolConfig returns a new LIFO pool, with the same optional health
+// checking as NewChannelPoolConfig: conns failing the IsActive check or
+// exceeding MaxIdleTime/MaxLifetime are discarded and replaced instead of
+// being handed out.
+func NewStackPoolConfig(cfg *ChannelPoolConfig) (Pool, error) {
+	inner, err := NewGenStackPool(&GenChannelPoolConfig[net.Conn]{
+		InitialCap: cfg.InitialCap,
+		MaxCap:     cfg.MaxCap,
+		Factory:    cfg.Factory,
+		Close:      func(conn net.Conn) error { return conn.Close() },
+
+		IsActive:    cfg.IsActive,
+		MaxIdleTime: cfg.MaxIdleTime,
+		MaxLifetime: cfg.MaxLifetime,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &channelPool{inner: inner}, nil
+}
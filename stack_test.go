@@ -0,0 +1,92 @@
+package spool
+
+import (
+	"net"
+	"testing"
+	"time"
+)
+
+func newTestStackPool(t *testing.T, initialCap, maxCap int) *channelPool {
+	t.Helper()
+	p, err := NewStackPool(initialCap, maxCap, func() (net.Conn, error) {
+		return &fakeConn{}, nil
+	})
+	if err != nil {
+		t.Fatalf("NewStackPool: %v", err)
+	}
+	return p.(*channelPool)
+}
+
+func TestStackPool_LIFOHandOutOrder(t *testing.T) {
+	p := newTestStackPool(t, 0, 2)
+	defer p.Close()
+
+	first, err := p.Get()
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	second, err := p.Get()
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+
+	if err := first.Close(); err != nil {
+		t.Fatalf("Close first: %v", err)
+	}
+	if err := second.Close(); err != nil {
+		t.Fatalf("Close second: %v", err)
+	}
+
+	// LIFO: the most recently released conn (second) must come back first.
+	got, err := p.Get()
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if got.(*PoolConn).Conn != second.(*PoolConn).Conn {
+		t.Fatal("expected most recently released conn to be handed out first")
+	}
+}
+
+func TestStackPool_MarkUnusable_ClosesUnderlyingConn(t *testing.T) {
+	p := newTestStackPool(t, 1, 1)
+	defer p.Close()
+
+	conn, err := p.Get()
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	pc := conn.(*PoolConn)
+	fc := pc.Conn.(*fakeConn)
+
+	pc.MarkUnusable()
+	if err := pc.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	if !fc.isClosed() {
+		t.Fatal("expected underlying conn to be closed, it wasn't")
+	}
+	if p.Len() != 0 {
+		t.Fatalf("expected pool to stay empty after discarding an unusable conn, got Len()=%d", p.Len())
+	}
+
+	conn2, err := p.Get()
+	if err != nil {
+		t.Fatalf("Get after discard: %v", err)
+	}
+	conn2.Close()
+}
+
+func TestStackPool_GetWithTimeout_ReturnsErrWhenExhausted(t *testing.T) {
+	p := newTestStackPool(t, 1, 1)
+	defer p.Close()
+
+	conn, err := p.Get()
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	defer conn.Close()
+
+	if _, err := p.GetWithTimeout(10 * time.Millisecond); err == nil {
+		t.Fatal("expected GetWithTimeout to time out on an exhausted pool")
+	}
+}